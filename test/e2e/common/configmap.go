@@ -0,0 +1,243 @@
+/*
+Copyright 2019 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package common
+
+import (
+	"fmt"
+
+	"k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/uuid"
+	"k8s.io/kubernetes/test/e2e/framework"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("[sig-node] ConfigMap", func() {
+	f := framework.NewDefaultFramework("configmap")
+
+	/*
+	   Release : v1.9
+	   Testname: ConfigMap, envFrom
+	   Description: A ConfigMap is created. A Pod is created with a container whose EnvFrom references the ConfigMap, optionally with a Prefix. Every key in the ConfigMap MUST be present in the container's environment, with the Prefix (if any) prepended to the name.
+	*/
+	framework.ConformanceIt("should be consumable via the environment [NodeConformance]", func() {
+		name := "configmap-envfrom-test-" + string(uuid.NewUUID())
+		configMap := newEnvFromConfigMap(f, name)
+
+		By(fmt.Sprintf("Creating configMap %v/%v", f.Namespace.Name, configMap.Name))
+		var err error
+		if configMap, err = f.ClientSet.CoreV1().ConfigMaps(f.Namespace.Name).Create(configMap); err != nil {
+			framework.Failf("unable to create test configMap %s: %v", configMap.Name, err)
+		}
+
+		pod := newEnvFromPod(f, "pod-configmaps-envfrom-"+string(uuid.NewUUID()), []v1.EnvFromSource{
+			{
+				Prefix:       "p_",
+				ConfigMapRef: &v1.ConfigMapEnvSource{LocalObjectReference: v1.LocalObjectReference{Name: name}},
+			},
+		})
+
+		f.TestContainerOutput("consume configMaps via envFrom", pod, 0, []string{
+			"p_data_1=value-1", "p_data_2=value-2",
+		})
+	})
+
+	It("should skip keys that are not valid environment variable names when consumed via envFrom [NodeConformance]", func() {
+		name := "configmap-envfrom-invalid-key-test-" + string(uuid.NewUUID())
+		configMap := newEnvFromConfigMap(f, name)
+		configMap.Data["1BAD_KEY"] = "value-3"
+
+		By(fmt.Sprintf("Creating configMap %v/%v", f.Namespace.Name, configMap.Name))
+		var err error
+		if configMap, err = f.ClientSet.CoreV1().ConfigMaps(f.Namespace.Name).Create(configMap); err != nil {
+			framework.Failf("unable to create test configMap %s: %v", configMap.Name, err)
+		}
+
+		pod := newEnvFromPod(f, "pod-configmaps-envfrom-invalid-key-"+string(uuid.NewUUID()), []v1.EnvFromSource{
+			{ConfigMapRef: &v1.ConfigMapEnvSource{LocalObjectReference: v1.LocalObjectReference{Name: name}}},
+		})
+
+		f.TestContainerOutput("consume configMaps via envFrom, skipping the invalid key", pod, 0, []string{
+			"data_1=value-1", "data_2=value-2",
+		})
+
+		By("verifying an event was recorded for the skipped invalid environment variable name")
+		events, err := f.ClientSet.CoreV1().Events(f.Namespace.Name).List(metav1.ListOptions{})
+		framework.ExpectNoError(err, "failed to list events in namespace %q", f.Namespace.Name)
+		foundSkipEvent := false
+		for _, event := range events.Items {
+			if event.InvolvedObject.Name == pod.Name && event.Reason == "InvalidEnvironmentVariableNames" {
+				foundSkipEvent = true
+				break
+			}
+		}
+		Expect(foundSkipEvent).To(BeTrue(), "expected an event recording that key %q was skipped", "1BAD_KEY")
+	})
+
+	/*
+	   Release : v1.9
+	   Testname: ConfigMap, configMapKeyRef
+	   Description: A ConfigMap is created. A Pod is created with a container whose Env references the ConfigMap via configMapKeyRef. Pod MUST be able to consume the value of the referenced key successfully.
+	*/
+	framework.ConformanceIt("should be consumable via the environment variable [NodeConformance]", func() {
+		name := "configmap-env-test-" + string(uuid.NewUUID())
+		configMap := newEnvFromConfigMap(f, name)
+
+		By(fmt.Sprintf("Creating configMap %v/%v", f.Namespace.Name, configMap.Name))
+		var err error
+		if configMap, err = f.ClientSet.CoreV1().ConfigMaps(f.Namespace.Name).Create(configMap); err != nil {
+			framework.Failf("unable to create test configMap %s: %v", configMap.Name, err)
+		}
+
+		pod := &v1.Pod{
+			ObjectMeta: metav1.ObjectMeta{
+				Name: "pod-configmaps-" + string(uuid.NewUUID()),
+			},
+			Spec: v1.PodSpec{
+				Containers: []v1.Container{
+					{
+						Name:    "env-test",
+						Image:   "k8s.gcr.io/busybox",
+						Command: []string{"sh", "-c", "env"},
+						Env: []v1.EnvVar{
+							{
+								Name: "CONFIG_DATA_1",
+								ValueFrom: &v1.EnvVarSource{
+									ConfigMapKeyRef: &v1.ConfigMapKeySelector{
+										LocalObjectReference: v1.LocalObjectReference{Name: name},
+										Key:                  "data_1",
+									},
+								},
+							},
+						},
+					},
+				},
+				RestartPolicy: v1.RestartPolicyNever,
+			},
+		}
+
+		f.TestContainerOutput("consume configMaps via environment variable", pod, 0, []string{
+			"CONFIG_DATA_1=value-1",
+		})
+	})
+
+	It("should be consumable via the environment for optional configmaps [NodeConformance]", func() {
+		name := "configmap-env-optional-" + string(uuid.NewUUID())
+		falseVal := false
+		trueVal := true
+
+		pod := newEnvFromPod(f, "pod-configmaps-optional-"+string(uuid.NewUUID()), []v1.EnvFromSource{
+			{
+				ConfigMapRef: &v1.ConfigMapEnvSource{
+					LocalObjectReference: v1.LocalObjectReference{Name: name + "-missing"},
+					Optional:             &trueVal,
+				},
+			},
+		})
+		pod.Spec.RestartPolicy = v1.RestartPolicyNever
+
+		By("Creating the pod with an Optional envFrom referencing a missing configMap")
+		f.PodClient().CreateSync(pod)
+
+		By("Creating a pod with a required envFrom referencing a missing configMap, which should not start")
+		blockedPod := newEnvFromPod(f, "pod-configmaps-required-missing-"+string(uuid.NewUUID()), []v1.EnvFromSource{
+			{
+				ConfigMapRef: &v1.ConfigMapEnvSource{
+					LocalObjectReference: v1.LocalObjectReference{Name: name + "-missing"},
+					Optional:             &falseVal,
+				},
+			},
+		})
+		blockedPod.Spec.RestartPolicy = v1.RestartPolicyNever
+		blockedPod = f.PodClient().Create(blockedPod)
+
+		err := framework.WaitForPodNameRunningInNamespace(f.ClientSet, blockedPod.Name, f.Namespace.Name)
+		Expect(err).To(HaveOccurred(), "pod %q unexpectedly started running with a required envFrom referencing a missing configMap", blockedPod.Name)
+	})
+
+	It("should not be updated after pod creation when it is mounted as env vars [NodeConformance]", func() {
+		name := "configmap-env-no-refresh-" + string(uuid.NewUUID())
+		configMap := newEnvFromConfigMap(f, name)
+
+		By(fmt.Sprintf("Creating configMap %v/%v", f.Namespace.Name, configMap.Name))
+		var err error
+		if configMap, err = f.ClientSet.CoreV1().ConfigMaps(f.Namespace.Name).Create(configMap); err != nil {
+			framework.Failf("unable to create test configMap %s: %v", configMap.Name, err)
+		}
+
+		pod := newEnvFromPod(f, "pod-configmaps-no-refresh-"+string(uuid.NewUUID()), []v1.EnvFromSource{
+			{ConfigMapRef: &v1.ConfigMapEnvSource{LocalObjectReference: v1.LocalObjectReference{Name: name}}},
+		})
+
+		By("Creating the pod")
+		f.PodClient().CreateSync(pod)
+
+		By(fmt.Sprintf("Updating configmap %v", configMap.Name))
+		configMap.ResourceVersion = ""
+		configMap.Data["data_1"] = "value-changed"
+		_, err = f.ClientSet.CoreV1().ConfigMaps(f.Namespace.Name).Update(configMap)
+		Expect(err).NotTo(HaveOccurred(), "Failed to update configmap %q in namespace %q", configMap.Name, f.Namespace.Name)
+
+		By("verifying the pod's environment was not refreshed with the new value")
+		logs, err := framework.GetPodLogs(f.ClientSet, f.Namespace.Name, pod.Name, pod.Spec.Containers[0].Name)
+		framework.ExpectNoError(err, "failed to get pod logs")
+		Expect(logs).To(ContainSubstring("data_1=value-1"))
+		Expect(logs).NotTo(ContainSubstring("value-changed"))
+	})
+})
+
+// newEnvFromConfigMap returns a ConfigMap whose keys are already valid
+// environment variable names, for use by the envFrom/configMapKeyRef tests.
+func newEnvFromConfigMap(f *framework.Framework, name string) *v1.ConfigMap {
+	return &v1.ConfigMap{
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace: f.Namespace.Name,
+			Name:      name,
+		},
+		Data: map[string]string{
+			"data_1": "value-1",
+			"data_2": "value-2",
+		},
+	}
+}
+
+// newEnvFromPod returns a single-container Pod whose container's EnvFrom is
+// set to envFrom and which dumps its environment before sleeping, for use by
+// the envFrom conformance tests. The container is kept alive after printing
+// its environment so that callers driving the pod via CreateSync can rely on
+// observing it Running rather than racing a container that exits the moment
+// "env" completes.
+func newEnvFromPod(f *framework.Framework, podName string, envFrom []v1.EnvFromSource) *v1.Pod {
+	return &v1.Pod{
+		ObjectMeta: metav1.ObjectMeta{
+			Name: podName,
+		},
+		Spec: v1.PodSpec{
+			Containers: []v1.Container{
+				{
+					Name:    "env-test",
+					Image:   "k8s.gcr.io/busybox",
+					Command: []string{"sh", "-c", "env; sleep 3600"},
+					EnvFrom: envFrom,
+				},
+			},
+			RestartPolicy: v1.RestartPolicyNever,
+		},
+	}
+}
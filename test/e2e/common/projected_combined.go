@@ -0,0 +1,291 @@
+/*
+Copyright 2019 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package common
+
+import (
+	"fmt"
+	"os"
+
+	"k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/fields"
+	"k8s.io/apimachinery/pkg/util/uuid"
+	"k8s.io/kubernetes/test/e2e/framework"
+	imageutils "k8s.io/kubernetes/test/utils/image"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("[sig-storage] Projected combined", func() {
+	f := framework.NewDefaultFramework("projected")
+
+	/*
+	   Release : v1.16
+	   Testname: Projected Volume, multiple sources
+	   Description: A Pod is created with a single projected volume whose Sources list mixes a ConfigMap, a Secret, a DownwardAPI and a ServiceAccountToken projection into the same mount directory. Pod MUST be able to read the content from every source, with each source's Items mappings and Path prefixes keeping the resulting files collision-free.
+	*/
+	framework.ConformanceIt("should project all components that make up the projection API [Projection][NodeConformance]", func() {
+		doProjectedCombinedE2E(f)
+	})
+
+	/*
+	   Testname: Projected Volume, conflicting paths
+	   Description: A Pod is created with a single projected volume whose Sources list has two projections (a ConfigMap and a Secret) that both map a key to the same relative Path. The kubelet MUST reject the volume and surface a FailedMount event naming the conflicting duplicate path.
+	*/
+	It("should report error when two projected sources map keys to the same path [NodeConformance]", func() {
+		doProjectedCombinedConflictingPathsE2E(f)
+	})
+})
+
+func doProjectedCombinedE2E(f *framework.Framework) {
+	var (
+		name          = "projected-combined-" + string(uuid.NewUUID())
+		configMapName = "configmap-" + name
+		secretName    = "secret-" + name
+		volumeName    = "projected-combined-volume"
+		mountPath     = "/etc/projected-combined-volume"
+		podName       = "pod-projected-combined-" + string(uuid.NewUUID())
+	)
+
+	secretKeyMode := int32(0400)
+	secretKeyModeString := fmt.Sprintf("%v", os.FileMode(secretKeyMode))
+	configMap := newConfigMap(f, configMapName)
+	secret := &v1.Secret{
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace: f.Namespace.Name,
+			Name:      secretName,
+		},
+		Data: map[string][]byte{
+			"secret-key": []byte("secret-value"),
+		},
+	}
+
+	By(fmt.Sprintf("Creating configMap with name %s", configMap.Name))
+	var err error
+	if configMap, err = f.ClientSet.CoreV1().ConfigMaps(f.Namespace.Name).Create(configMap); err != nil {
+		framework.Failf("unable to create test configMap %s: %v", configMap.Name, err)
+	}
+
+	By(fmt.Sprintf("Creating secret with name %s", secret.Name))
+	if secret, err = f.ClientSet.CoreV1().Secrets(f.Namespace.Name).Create(secret); err != nil {
+		framework.Failf("unable to create test secret %s: %v", secret.Name, err)
+	}
+
+	pod := &v1.Pod{
+		ObjectMeta: metav1.ObjectMeta{
+			Name: podName,
+			Labels: map[string]string{
+				"fruit": "mango",
+			},
+			Annotations: map[string]string{
+				"builder": "bar",
+			},
+		},
+		Spec: v1.PodSpec{
+			Volumes: []v1.Volume{
+				{
+					Name: volumeName,
+					VolumeSource: v1.VolumeSource{
+						Projected: &v1.ProjectedVolumeSource{
+							Sources: []v1.VolumeProjection{
+								{
+									ConfigMap: &v1.ConfigMapProjection{
+										LocalObjectReference: v1.LocalObjectReference{
+											Name: configMap.Name,
+										},
+										Items: []v1.KeyToPath{
+											{Key: "data-1", Path: "configmap/data-1"},
+										},
+									},
+								},
+								{
+									Secret: &v1.SecretProjection{
+										LocalObjectReference: v1.LocalObjectReference{
+											Name: secret.Name,
+										},
+										Items: []v1.KeyToPath{
+											{Key: "secret-key", Path: "secret/secret-key", Mode: &secretKeyMode},
+										},
+									},
+								},
+								{
+									DownwardAPI: &v1.DownwardAPIProjection{
+										Items: []v1.DownwardAPIVolumeFile{
+											{
+												Path:     "downwardapi/labels",
+												FieldRef: &v1.ObjectFieldSelector{FieldPath: "metadata.labels"},
+											},
+											{
+												Path:     "downwardapi/annotations",
+												FieldRef: &v1.ObjectFieldSelector{FieldPath: "metadata.annotations"},
+											},
+										},
+									},
+								},
+								{
+									ServiceAccountToken: &v1.ServiceAccountTokenProjection{
+										Path: "serviceaccounttoken/token",
+									},
+								},
+							},
+						},
+					},
+				},
+			},
+			Containers: []v1.Container{
+				{
+					Name:  "projected-combined-volume-test",
+					Image: imageutils.GetE2EImage(imageutils.Mounttest),
+					Args: []string{
+						"--file_content=" + mountPath + "/configmap/data-1",
+						"--file_content=" + mountPath + "/secret/secret-key",
+						"--file_mode=" + mountPath + "/secret/secret-key",
+						"--file_content=" + mountPath + "/downwardapi/labels",
+						"--file_content=" + mountPath + "/downwardapi/annotations",
+						"--file_content=" + mountPath + "/serviceaccounttoken/token",
+					},
+					VolumeMounts: []v1.VolumeMount{
+						{
+							Name:      volumeName,
+							MountPath: mountPath,
+							ReadOnly:  true,
+						},
+					},
+				},
+			},
+			RestartPolicy: v1.RestartPolicyNever,
+		},
+	}
+
+	By("Creating the pod")
+	f.PodClient().CreateSync(pod)
+
+	logs, err := framework.GetPodLogs(f.ClientSet, f.Namespace.Name, pod.Name, pod.Spec.Containers[0].Name)
+	framework.ExpectNoError(err, "failed to get pod logs")
+
+	By("verifying the configMap source was projected")
+	Expect(logs).To(ContainSubstring(fmt.Sprintf("content of file %q: value-1", mountPath+"/configmap/data-1")))
+
+	By("verifying the secret source was projected with its per-item mode")
+	Expect(logs).To(ContainSubstring(fmt.Sprintf("content of file %q: secret-value", mountPath+"/secret/secret-key")))
+	Expect(logs).To(ContainSubstring(fmt.Sprintf("mode of file %q: %s", mountPath+"/secret/secret-key", secretKeyModeString)))
+
+	By("verifying the downwardAPI source was projected")
+	Expect(logs).To(ContainSubstring("fruit=\"mango\""))
+	Expect(logs).To(ContainSubstring("builder=\"bar\""))
+
+	By("verifying the serviceAccountToken source was projected")
+	Expect(extractMountedFileContent(logs, mountPath+"/serviceaccounttoken/token")).NotTo(BeEmpty())
+}
+
+func doProjectedCombinedConflictingPathsE2E(f *framework.Framework) {
+	var (
+		name          = "projected-combined-conflict-" + string(uuid.NewUUID())
+		configMapName = "configmap-" + name
+		secretName    = "secret-" + name
+		volumeName    = "projected-combined-volume"
+		mountPath     = "/etc/projected-combined-volume"
+		podName       = "pod-projected-combined-conflict-" + string(uuid.NewUUID())
+	)
+
+	configMap := newConfigMap(f, configMapName)
+	secret := &v1.Secret{
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace: f.Namespace.Name,
+			Name:      secretName,
+		},
+		Data: map[string][]byte{
+			"data-1": []byte("value-1"),
+		},
+	}
+
+	By(fmt.Sprintf("Creating configMap with name %s", configMap.Name))
+	var err error
+	if configMap, err = f.ClientSet.CoreV1().ConfigMaps(f.Namespace.Name).Create(configMap); err != nil {
+		framework.Failf("unable to create test configMap %s: %v", configMap.Name, err)
+	}
+
+	By(fmt.Sprintf("Creating secret with name %s", secret.Name))
+	if secret, err = f.ClientSet.CoreV1().Secrets(f.Namespace.Name).Create(secret); err != nil {
+		framework.Failf("unable to create test secret %s: %v", secret.Name, err)
+	}
+
+	pod := &v1.Pod{
+		ObjectMeta: metav1.ObjectMeta{
+			Name: podName,
+		},
+		Spec: v1.PodSpec{
+			Volumes: []v1.Volume{
+				{
+					Name: volumeName,
+					VolumeSource: v1.VolumeSource{
+						Projected: &v1.ProjectedVolumeSource{
+							Sources: []v1.VolumeProjection{
+								{
+									ConfigMap: &v1.ConfigMapProjection{
+										LocalObjectReference: v1.LocalObjectReference{Name: configMap.Name},
+										Items: []v1.KeyToPath{
+											{Key: "data-1", Path: "data"},
+										},
+									},
+								},
+								{
+									Secret: &v1.SecretProjection{
+										LocalObjectReference: v1.LocalObjectReference{Name: secret.Name},
+										Items: []v1.KeyToPath{
+											{Key: "data-1", Path: "data"},
+										},
+									},
+								},
+							},
+						},
+					},
+				},
+			},
+			Containers: []v1.Container{
+				{
+					Name:    "projected-combined-volume-test",
+					Image:   imageutils.GetE2EImage(imageutils.Mounttest),
+					Command: []string{"/mounttest", "--file_content=/etc/projected-combined-volume/data"},
+					VolumeMounts: []v1.VolumeMount{
+						{
+							Name:      volumeName,
+							MountPath: mountPath,
+							ReadOnly:  true,
+						},
+					},
+				},
+			},
+			RestartPolicy: v1.RestartPolicyNever,
+		},
+	}
+
+	By("Creating the pod")
+	pod = f.PodClient().Create(pod)
+
+	By("waiting for the pod to report a conflicting duplicate paths event")
+	expectedMsg := "project volume doesn't support conflicting duplicate paths"
+	eventSelector := fields.Set{
+		"involvedObject.kind":      "Pod",
+		"involvedObject.name":      pod.Name,
+		"involvedObject.namespace": f.Namespace.Name,
+		"reason":                   "FailedMount",
+	}.AsSelector().String()
+	err = framework.WaitTimeoutForPodEvent(f.ClientSet, pod.Name, f.Namespace.Name, eventSelector, expectedMsg, framework.PodStartTimeout)
+	framework.ExpectNoError(err, "error waiting for pod event with conflicting duplicate paths message")
+}
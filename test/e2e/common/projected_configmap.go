@@ -17,11 +17,14 @@ limitations under the License.
 package common
 
 import (
+	"encoding/hex"
 	"fmt"
 	"os"
 	"path"
+	"strings"
 
 	"k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/util/uuid"
 	"k8s.io/kubernetes/test/e2e/framework"
@@ -114,7 +117,6 @@ var _ = Describe("[sig-storage] Projected configMap", func() {
 
 		name := "projected-configmap-test-upd-" + string(uuid.NewUUID())
 		volumeName := "projected-configmap-volume"
-		volumeMountPath := "/etc/projected-configmap-volume"
 		containerName := "projected-configmap-volume-test"
 		configMap := &v1.ConfigMap{
 			ObjectMeta: metav1.ObjectMeta{
@@ -132,46 +134,7 @@ var _ = Describe("[sig-storage] Projected configMap", func() {
 			framework.Failf("unable to create test configMap %s: %v", configMap.Name, err)
 		}
 
-		pod := &v1.Pod{
-			ObjectMeta: metav1.ObjectMeta{
-				Name: "pod-projected-configmaps-" + string(uuid.NewUUID()),
-			},
-			Spec: v1.PodSpec{
-				Volumes: []v1.Volume{
-					{
-						Name: volumeName,
-						VolumeSource: v1.VolumeSource{
-							Projected: &v1.ProjectedVolumeSource{
-								Sources: []v1.VolumeProjection{
-									{
-										ConfigMap: &v1.ConfigMapProjection{
-											LocalObjectReference: v1.LocalObjectReference{
-												Name: name,
-											},
-										},
-									},
-								},
-							},
-						},
-					},
-				},
-				Containers: []v1.Container{
-					{
-						Name:    containerName,
-						Image:   imageutils.GetE2EImage(imageutils.Mounttest),
-						Command: []string{"/mounttest", "--break_on_expected_content=false", containerTimeoutArg, "--file_content_in_loop=/etc/projected-configmap-volume/data-1"},
-						VolumeMounts: []v1.VolumeMount{
-							{
-								Name:      volumeName,
-								MountPath: volumeMountPath,
-								ReadOnly:  true,
-							},
-						},
-					},
-				},
-				RestartPolicy: v1.RestartPolicyNever,
-			},
-		}
+		pod := projectedConfigMapUpdatePod(volumeName, containerName, name, containerTimeoutArg)
 		By("Creating the pod")
 		f.PodClient().CreateSync(pod)
 
@@ -191,6 +154,150 @@ var _ = Describe("[sig-storage] Projected configMap", func() {
 		Eventually(pollLogs, podLogTimeout, framework.Poll).Should(ContainSubstring("value-2"))
 	})
 
+	/*
+	   Testname: Projected Volume, ConfigMap, immutable
+	   Description: An immutable ConfigMap is projected into a volume. The Pod MUST be able to read the content. Attempts to update the ConfigMap's Data, BinaryData, or to flip Immutable back to false MUST be rejected by the API server with an Invalid error.
+	*/
+	It("should be immutable if `immutable` field is set [NodeConformance]", func() {
+		name := "projected-immutable-configmap-test-" + string(uuid.NewUUID())
+		volumeName := "projected-configmap-volume"
+		containerName := "projected-configmap-volume-test"
+		trueVal := true
+		configMap := &v1.ConfigMap{
+			ObjectMeta: metav1.ObjectMeta{
+				Namespace: f.Namespace.Name,
+				Name:      name,
+			},
+			Data: map[string]string{
+				"data-1": "value-1",
+			},
+			Immutable: &trueVal,
+		}
+
+		By(fmt.Sprintf("Creating immutable configMap with name %s", configMap.Name))
+		var err error
+		if configMap, err = f.ClientSet.CoreV1().ConfigMaps(f.Namespace.Name).Create(configMap); err != nil {
+			framework.Failf("unable to create test configMap %s: %v", configMap.Name, err)
+		}
+
+		pod := projectedConfigMapUpdatePod(volumeName, containerName, name, "--retry_time=0")
+		By("Creating the pod")
+		f.PodClient().CreateSync(pod)
+
+		pollLogs := func() (string, error) {
+			return framework.GetPodLogs(f.ClientSet, f.Namespace.Name, pod.Name, containerName)
+		}
+		Eventually(pollLogs, framework.GetPodSecretUpdateTimeout(f.ClientSet), framework.Poll).Should(ContainSubstring("value-1"))
+
+		By("Attempting to update data of an immutable configmap")
+		toUpdate := configMap.DeepCopy()
+		toUpdate.ResourceVersion = ""
+		toUpdate.Data["data-1"] = "value-2"
+		_, err = f.ClientSet.CoreV1().ConfigMaps(f.Namespace.Name).Update(toUpdate)
+		Expect(err).To(HaveOccurred())
+		Expect(apierrors.IsInvalid(err)).To(BeTrue(), "expected an Invalid error updating Data of immutable configmap %q, got: %v", configMap.Name, err)
+
+		By("Attempting to update binaryData of an immutable configmap")
+		toUpdate = configMap.DeepCopy()
+		toUpdate.ResourceVersion = ""
+		toUpdate.BinaryData = map[string][]byte{"bin-data-1": []byte("value-2")}
+		_, err = f.ClientSet.CoreV1().ConfigMaps(f.Namespace.Name).Update(toUpdate)
+		Expect(err).To(HaveOccurred())
+		Expect(apierrors.IsInvalid(err)).To(BeTrue(), "expected an Invalid error updating BinaryData of immutable configmap %q, got: %v", configMap.Name, err)
+
+		By("Attempting to set Immutable back to false on an immutable configmap")
+		falseVal := false
+		toUpdate = configMap.DeepCopy()
+		toUpdate.ResourceVersion = ""
+		toUpdate.Immutable = &falseVal
+		_, err = f.ClientSet.CoreV1().ConfigMaps(f.Namespace.Name).Update(toUpdate)
+		Expect(err).To(HaveOccurred())
+		Expect(apierrors.IsInvalid(err)).To(BeTrue(), "expected an Invalid error setting Immutable to false on configmap %q, got: %v", configMap.Name, err)
+
+		By("Attempting to unset Immutable on an immutable configmap")
+		toUpdate = configMap.DeepCopy()
+		toUpdate.ResourceVersion = ""
+		toUpdate.Immutable = nil
+		_, err = f.ClientSet.CoreV1().ConfigMaps(f.Namespace.Name).Update(toUpdate)
+		Expect(err).To(HaveOccurred())
+		Expect(apierrors.IsInvalid(err)).To(BeTrue(), "expected an Invalid error unsetting Immutable on configmap %q, got: %v", configMap.Name, err)
+	})
+
+	/*
+	   Testname: Projected Volume, ConfigMap, immutable, delete and recreate
+	   Description: An immutable ConfigMap mounted by a running Pod is deleted and recreated under the same name with new data. The kubelet MUST NOT re-sync the immutable projection into the already-running Pod, while a new Pod created afterwards MUST see the new data.
+	*/
+	It("should not be resynced to a running pod after an immutable ConfigMap is deleted and recreated [NodeConformance]", func() {
+		name := "projected-immutable-configmap-recreate-" + string(uuid.NewUUID())
+		volumeName := "projected-configmap-volume"
+		containerName := "projected-configmap-volume-test"
+		trueVal := true
+		configMap := &v1.ConfigMap{
+			ObjectMeta: metav1.ObjectMeta{
+				Namespace: f.Namespace.Name,
+				Name:      name,
+			},
+			Data: map[string]string{
+				"data-1": "value-1",
+			},
+			Immutable: &trueVal,
+		}
+
+		By(fmt.Sprintf("Creating immutable configMap with name %s", configMap.Name))
+		var err error
+		if configMap, err = f.ClientSet.CoreV1().ConfigMaps(f.Namespace.Name).Create(configMap); err != nil {
+			framework.Failf("unable to create test configMap %s: %v", configMap.Name, err)
+		}
+
+		existingPod := projectedConfigMapUpdatePod(volumeName, containerName, name, "--retry_time=0")
+		existingPod.Name = "pod-projected-configmaps-existing-" + string(uuid.NewUUID())
+		By("Creating a pod that mounts the immutable configMap")
+		f.PodClient().CreateSync(existingPod)
+
+		pollExistingLogs := func() (string, error) {
+			return framework.GetPodLogs(f.ClientSet, f.Namespace.Name, existingPod.Name, containerName)
+		}
+		Eventually(pollExistingLogs, framework.GetPodSecretUpdateTimeout(f.ClientSet), framework.Poll).Should(ContainSubstring("value-1"))
+
+		By(fmt.Sprintf("Deleting configmap %v", configMap.Name))
+		err = f.ClientSet.CoreV1().ConfigMaps(f.Namespace.Name).Delete(configMap.Name, &metav1.DeleteOptions{})
+		Expect(err).NotTo(HaveOccurred(), "Failed to delete configmap %q in namespace %q", configMap.Name, f.Namespace.Name)
+
+		By(fmt.Sprintf("Recreating configmap %v with new data", configMap.Name))
+		recreated := &v1.ConfigMap{
+			ObjectMeta: metav1.ObjectMeta{
+				Namespace: f.Namespace.Name,
+				Name:      name,
+			},
+			Data: map[string]string{
+				"data-1": "value-2",
+			},
+			Immutable: &trueVal,
+		}
+		if _, err = f.ClientSet.CoreV1().ConfigMaps(f.Namespace.Name).Create(recreated); err != nil {
+			framework.Failf("unable to recreate test configMap %s: %v", recreated.Name, err)
+		}
+
+		newPod := projectedConfigMapUpdatePod(volumeName, containerName, name, "--retry_time=0")
+		newPod.Name = "pod-projected-configmaps-new-" + string(uuid.NewUUID())
+		By("Creating a new pod that mounts the recreated configMap")
+		f.PodClient().CreateSync(newPod)
+
+		pollNewLogs := func() (string, error) {
+			return framework.GetPodLogs(f.ClientSet, f.Namespace.Name, newPod.Name, containerName)
+		}
+		Eventually(pollNewLogs, framework.GetPodSecretUpdateTimeout(f.ClientSet), framework.Poll).Should(ContainSubstring("value-2"))
+
+		By("verifying the existing pod still observes the old data")
+		// Pod logs are cumulative, so the early "value-1" lines are always
+		// present even if the kubelet later resynced the volume; only the
+		// absence of "value-2" actually proves no resync happened.
+		Consistently(pollExistingLogs, framework.GetPodSecretUpdateTimeout(f.ClientSet)/2, framework.Poll).Should(ContainSubstring("value-1"))
+		existingLogs, err := pollExistingLogs()
+		framework.ExpectNoError(err, "failed to get pod logs")
+		Expect(existingLogs).NotTo(ContainSubstring("value-2"))
+	})
+
 	/*
 	   Release : v1.9
 	   Testname: Projected Volume, ConfigMap, create, update and delete
@@ -481,8 +588,471 @@ var _ = Describe("[sig-storage] Projected configMap", func() {
 		})
 
 	})
+
+	/*
+	   Testname: Projected Volume, ConfigMap, binary data
+	   Description: A ConfigMap populated with non-UTF-8 BinaryData is projected into a volume. Pod MUST be able to read the binary content back byte-for-byte, verified by comparing a hex dump the container itself prints of the mounted file rather than by piping the raw non-UTF-8 bytes through pod logs, which do not reliably preserve them.
+	*/
+	It("should be consumable from pods in volume with binary data [NodeConformance]", func() {
+		var (
+			name            = "projected-configmap-test-binary-" + string(uuid.NewUUID())
+			volumeName      = "projected-configmap-volume"
+			volumeMountPath = "/etc/projected-configmap-volume"
+			containerName   = "projected-configmap-volume-test"
+			filePath        = volumeMountPath + "/bin-data-1"
+			configMap       = newBinaryConfigMap(f, name)
+		)
+
+		By(fmt.Sprintf("Creating configMap with name %s", configMap.Name))
+		var err error
+		if configMap, err = f.ClientSet.CoreV1().ConfigMaps(f.Namespace.Name).Create(configMap); err != nil {
+			framework.Failf("unable to create test configMap %s: %v", configMap.Name, err)
+		}
+
+		pod := &v1.Pod{
+			ObjectMeta: metav1.ObjectMeta{
+				Name: "pod-projected-configmaps-" + string(uuid.NewUUID()),
+			},
+			Spec: v1.PodSpec{
+				Volumes: []v1.Volume{
+					{
+						Name: volumeName,
+						VolumeSource: v1.VolumeSource{
+							Projected: &v1.ProjectedVolumeSource{
+								Sources: []v1.VolumeProjection{
+									{
+										ConfigMap: &v1.ConfigMapProjection{
+											LocalObjectReference: v1.LocalObjectReference{
+												Name: name,
+											},
+											Items: []v1.KeyToPath{
+												{Key: "bin-data-1", Path: "bin-data-1"},
+											},
+										},
+									},
+								},
+							},
+						},
+					},
+				},
+				Containers: []v1.Container{
+					{
+						Name:    containerName,
+						Image:   imageutils.GetE2EImage(imageutils.Mounttest),
+						Command: []string{"sh", "-c", hexDumpCommand(filePath)},
+						VolumeMounts: []v1.VolumeMount{
+							{
+								Name:      volumeName,
+								MountPath: volumeMountPath,
+								ReadOnly:  true,
+							},
+						},
+					},
+				},
+				RestartPolicy: v1.RestartPolicyNever,
+			},
+		}
+
+		By("Creating the pod")
+		f.PodClient().CreateSync(pod)
+
+		By("verifying the mounted file matches the BinaryData byte-for-byte")
+		logs, err := framework.GetPodLogs(f.ClientSet, f.Namespace.Name, pod.Name, containerName)
+		framework.ExpectNoError(err, "failed to get pod logs")
+		Expect(strings.TrimSpace(logs)).To(Equal(hex.EncodeToString(binaryConfigMapPayload)), "mounted file %s did not round-trip BinaryData byte-for-byte", filePath)
+	})
+
+	/*
+	   Testname: Projected Volume, ConfigMap, mixed Data and BinaryData
+	   Description: A ConfigMap that sets both Data and BinaryData is projected into a volume with a KeyToPath mapping for each. Pod MUST read back the string key as text and the binary key byte-for-byte.
+	*/
+	It("should be consumable from pods in volume with mixed data and binaryData and a mapping [NodeConformance]", func() {
+		var (
+			name            = "projected-configmap-test-mixed-" + string(uuid.NewUUID())
+			volumeName      = "projected-configmap-volume"
+			volumeMountPath = "/etc/projected-configmap-volume"
+			containerName   = "projected-configmap-volume-test"
+			textFilePath    = volumeMountPath + "/text/data-1"
+			binFilePath     = volumeMountPath + "/binary/bin-data-1"
+			configMap       = newBinaryConfigMap(f, name)
+		)
+		configMap.Data = map[string]string{"data-1": "value-1"}
+
+		By(fmt.Sprintf("Creating configMap with name %s", configMap.Name))
+		var err error
+		if configMap, err = f.ClientSet.CoreV1().ConfigMaps(f.Namespace.Name).Create(configMap); err != nil {
+			framework.Failf("unable to create test configMap %s: %v", configMap.Name, err)
+		}
+
+		pod := &v1.Pod{
+			ObjectMeta: metav1.ObjectMeta{
+				Name: "pod-projected-configmaps-" + string(uuid.NewUUID()),
+			},
+			Spec: v1.PodSpec{
+				Volumes: []v1.Volume{
+					{
+						Name: volumeName,
+						VolumeSource: v1.VolumeSource{
+							Projected: &v1.ProjectedVolumeSource{
+								Sources: []v1.VolumeProjection{
+									{
+										ConfigMap: &v1.ConfigMapProjection{
+											LocalObjectReference: v1.LocalObjectReference{
+												Name: name,
+											},
+											Items: []v1.KeyToPath{
+												{Key: "data-1", Path: "text/data-1"},
+												{Key: "bin-data-1", Path: "binary/bin-data-1"},
+											},
+										},
+									},
+								},
+							},
+						},
+					},
+				},
+				Containers: []v1.Container{
+					{
+						Name:  containerName,
+						Image: imageutils.GetE2EImage(imageutils.Mounttest),
+						Command: []string{"sh", "-c", fmt.Sprintf("echo TEXT:$(cat %s); echo BIN:$(%s)",
+							textFilePath, hexDumpCommand(binFilePath))},
+						VolumeMounts: []v1.VolumeMount{
+							{
+								Name:      volumeName,
+								MountPath: volumeMountPath,
+								ReadOnly:  true,
+							},
+						},
+					},
+				},
+				RestartPolicy: v1.RestartPolicyNever,
+			},
+		}
+
+		By("Creating the pod")
+		f.PodClient().CreateSync(pod)
+
+		logs, err := framework.GetPodLogs(f.ClientSet, f.Namespace.Name, pod.Name, containerName)
+		framework.ExpectNoError(err, "failed to get pod logs")
+
+		By("verifying the Data key was mounted as plain text")
+		Expect(logs).To(ContainSubstring("TEXT:value-1"))
+
+		By("verifying the BinaryData key round-tripped byte-for-byte")
+		Expect(logs).To(ContainSubstring("BIN:" + hex.EncodeToString(binaryConfigMapPayload)))
+	})
+
+	/*
+	   Testname: Projected Volume, ConfigMap, SubPath
+	   Description: A single key of a projected ConfigMap is mounted via VolumeMount.SubPath over an existing path inside the container image. Pod MUST be able to read the overlaid file while sibling files from the image remain visible.
+	*/
+	It("should be consumable as a subPath, without hiding sibling files [NodeConformance]", func() {
+		name := "projected-configmap-test-subpath-" + string(uuid.NewUUID())
+		containerName := "projected-configmap-volume-test"
+		configMap := newConfigMap(f, name)
+
+		By(fmt.Sprintf("Creating configMap with name %s", configMap.Name))
+		var err error
+		if configMap, err = f.ClientSet.CoreV1().ConfigMaps(f.Namespace.Name).Create(configMap); err != nil {
+			framework.Failf("unable to create test configMap %s: %v", configMap.Name, err)
+		}
+
+		volumeMounts := []v1.VolumeMount{
+			{
+				Name:      "projected-configmap-volume",
+				MountPath: "/etc/resolv.conf",
+				SubPath:   "data-1",
+			},
+		}
+		pod := projectedConfigMapSubpathPod(name, volumeMounts, nil)
+		pod.Spec.Containers[0].Name = containerName
+		// /etc/hosts is managed by the kubelet outside of any volume mount, so
+		// its continued presence shows the subPath overlay of /etc/resolv.conf
+		// did not hide the rest of /etc.
+		pod.Spec.Containers[0].Command = []string{"sh", "-c", "cat /etc/resolv.conf && test -e /etc/hosts && echo sibling-files-visible"}
+
+		By("Creating the pod")
+		f.PodClient().CreateSync(pod)
+
+		logs, err := framework.GetPodLogs(f.ClientSet, f.Namespace.Name, pod.Name, containerName)
+		framework.ExpectNoError(err, "failed to get pod logs")
+		Expect(logs).To(ContainSubstring("value-1"))
+		Expect(logs).To(ContainSubstring("sibling-files-visible"))
+	})
+
+	/*
+	   Testname: Projected Volume, ConfigMap, SubPath update
+	   Description: A single key of a projected ConfigMap is mounted via VolumeMount.SubPath. Unlike a whole-volume projection, a subPath mount is a bind mount of the single file resolved at mount time, so it does NOT receive ConfigMap updates. After the ConfigMap is updated, the subPath-mounted file MUST continue to reflect the original value for the remainder of the secret/configMap update timeout.
+	*/
+	It("should not propagate configMap updates to subPath-mounted files [NodeConformance]", func() {
+		podLogTimeout := framework.GetPodSecretUpdateTimeout(f.ClientSet)
+		containerTimeoutArg := fmt.Sprintf("--retry_time=%v", int(podLogTimeout.Seconds()))
+
+		name := "projected-configmap-test-subpath-upd-" + string(uuid.NewUUID())
+		containerName := "projected-configmap-volume-test"
+		configMap := newConfigMap(f, name)
+
+		By(fmt.Sprintf("Creating configMap with name %s", configMap.Name))
+		var err error
+		if configMap, err = f.ClientSet.CoreV1().ConfigMaps(f.Namespace.Name).Create(configMap); err != nil {
+			framework.Failf("unable to create test configMap %s: %v", configMap.Name, err)
+		}
+
+		volumeMounts := []v1.VolumeMount{
+			{
+				Name:      "projected-configmap-volume",
+				MountPath: "/etc/resolv.conf",
+				SubPath:   "data-1",
+			},
+		}
+		pod := projectedConfigMapSubpathPod(name, volumeMounts, nil)
+		pod.Spec.Containers[0].Name = containerName
+		pod.Spec.Containers[0].Command = []string{"/mounttest", "--break_on_expected_content=false", containerTimeoutArg, "--file_content_in_loop=/etc/resolv.conf"}
+
+		By("Creating the pod")
+		f.PodClient().CreateSync(pod)
+
+		pollLogs := func() (string, error) {
+			return framework.GetPodLogs(f.ClientSet, f.Namespace.Name, pod.Name, containerName)
+		}
+		Eventually(pollLogs, podLogTimeout, framework.Poll).Should(ContainSubstring("value-1"))
+
+		By(fmt.Sprintf("Updating configmap %v", configMap.Name))
+		configMap.ResourceVersion = ""
+		configMap.Data["data-1"] = "value-2"
+		_, err = f.ClientSet.CoreV1().ConfigMaps(f.Namespace.Name).Update(configMap)
+		Expect(err).NotTo(HaveOccurred(), "Failed to update configmap %q in namespace %q", configMap.Name, f.Namespace.Name)
+
+		By("verifying the subPath-mounted file keeps serving its original content")
+		Consistently(pollLogs, podLogTimeout, framework.Poll).ShouldNot(ContainSubstring("value-2"))
+	})
+
+	/*
+	   Testname: Projected Volume, ConfigMap, subPathExpr
+	   Description: A single key of a projected ConfigMap is mounted via VolumeMount.SubPathExpr, with the sub-path expanded from the pod's own downward-API-sourced POD_NAME environment variable. Pod MUST be able to read the file at the expanded sub-path.
+	*/
+	It("should resolve subPathExpr from the pod's own downward API environment [NodeConformance]", func() {
+		name := "projected-configmap-test-subpathexpr-" + string(uuid.NewUUID())
+		configMap := newConfigMap(f, name)
+
+		By(fmt.Sprintf("Creating configMap with name %s", configMap.Name))
+		var err error
+		if configMap, err = f.ClientSet.CoreV1().ConfigMaps(f.Namespace.Name).Create(configMap); err != nil {
+			framework.Failf("unable to create test configMap %s: %v", configMap.Name, err)
+		}
+
+		podName := "pod-projected-configmaps-subpathexpr-" + string(uuid.NewUUID())
+		volumeMounts := []v1.VolumeMount{
+			{
+				Name:        "projected-configmap-volume",
+				MountPath:   "/etc/podinfo",
+				SubPathExpr: "$(POD_NAME)",
+			},
+		}
+		env := []v1.EnvVar{
+			{
+				Name: "POD_NAME",
+				ValueFrom: &v1.EnvVarSource{
+					FieldRef: &v1.ObjectFieldSelector{FieldPath: "metadata.name"},
+				},
+			},
+		}
+		pod := projectedConfigMapSubpathPod(name, volumeMounts, env)
+		pod.Name = podName
+		pod.Spec.Volumes[0].VolumeSource.Projected.Sources[0].ConfigMap.Items = []v1.KeyToPath{
+			{Key: "data-1", Path: podName},
+		}
+		pod.Spec.Containers[0].Command = []string{"/mounttest", "--file_content=/etc/podinfo"}
+
+		f.PodClient().CreateSync(pod)
+
+		By("verifying the container reads the file at the subPathExpr-resolved path")
+		Eventually(func() (string, error) {
+			return framework.GetPodLogs(f.ClientSet, f.Namespace.Name, pod.Name, pod.Spec.Containers[0].Name)
+		}, framework.GetPodSecretUpdateTimeout(f.ClientSet), framework.Poll).Should(ContainSubstring("value-1"))
+	})
+
+	It("should reject a subPathExpr that resolves outside of the volume [NodeConformance]", func() {
+		name := "projected-configmap-test-subpathexpr-invalid-" + string(uuid.NewUUID())
+		configMap := newConfigMap(f, name)
+
+		By(fmt.Sprintf("Creating configMap with name %s", configMap.Name))
+		var err error
+		if configMap, err = f.ClientSet.CoreV1().ConfigMaps(f.Namespace.Name).Create(configMap); err != nil {
+			framework.Failf("unable to create test configMap %s: %v", configMap.Name, err)
+		}
+
+		volumeMounts := []v1.VolumeMount{
+			{
+				Name:        "projected-configmap-volume",
+				MountPath:   "/etc/podinfo",
+				SubPathExpr: "../$(POD_NAME)",
+			},
+		}
+		env := []v1.EnvVar{
+			{
+				Name: "POD_NAME",
+				ValueFrom: &v1.EnvVarSource{
+					FieldRef: &v1.ObjectFieldSelector{FieldPath: "metadata.name"},
+				},
+			},
+		}
+		pod := projectedConfigMapSubpathPod(name, volumeMounts, env)
+
+		By("verifying the apiserver rejects the pod at creation time")
+		_, err = f.ClientSet.CoreV1().Pods(f.Namespace.Name).Create(pod)
+		Expect(err).To(HaveOccurred(), "expected pod creation to be rejected for a subPathExpr resolving outside of the volume")
+		Expect(apierrors.IsInvalid(err)).To(BeTrue(), "expected an Invalid error for a subPathExpr containing '..', got: %v", err)
+	})
 })
 
+// binaryConfigMapPayload is a small, deliberately non-UTF-8 byte sequence
+// (a PNG header followed by the full 0x00-0xff byte range) used to verify
+// that projected ConfigMap volumes round-trip BinaryData exactly.
+var binaryConfigMapPayload = append([]byte{0x89, 'P', 'N', 'G', 0x0d, 0x0a, 0x1a, 0x0a}, allBytes()...)
+
+func allBytes() []byte {
+	b := make([]byte, 256)
+	for i := range b {
+		b[i] = byte(i)
+	}
+	return b
+}
+
+// hexDumpCommand returns a shell pipeline that prints the contents of the
+// file at path as a lowercase, newline-free hex string. BinaryData may
+// contain NUL bytes and other invalid UTF-8 that pod logs do not reliably
+// preserve, so tests that need a byte-exact comparison have the container
+// print a hex encoding of the whole file itself rather than piping raw bytes through
+// GetPodLogs.
+func hexDumpCommand(path string) string {
+	return fmt.Sprintf("od -An -v -tx1 %s | tr -d ' \\n'", path)
+}
+
+// extractMountedFileContent locates the output a single "/mounttest
+// --file_content=<path>" argument printed within a (possibly multi-argument)
+// mounttest invocation's logs and returns its raw bytes. mounttest prints
+// each file's content on its own "content of file %q: <content>" line; since
+// the content itself may contain arbitrary bytes (including newlines), the
+// end of the match is bounded by the start of the next such line rather than
+// by the next newline.
+func extractMountedFileContent(logs, path string) []byte {
+	const marker = `content of file "`
+	prefix := fmt.Sprintf("%s%s\": ", marker, path)
+	start := strings.Index(logs, prefix)
+	Expect(start).To(BeNumerically(">=", 0), "did not find %q in pod logs: %q", prefix, logs)
+	rest := logs[start+len(prefix):]
+	end := len(rest)
+	if next := strings.Index(rest, marker); next >= 0 {
+		end = next
+	}
+	return []byte(strings.TrimSuffix(rest[:end], "\n"))
+}
+
+// newBinaryConfigMap returns a ConfigMap populated with non-UTF-8 BinaryData,
+// the binary counterpart to newConfigMap.
+func newBinaryConfigMap(f *framework.Framework, name string) *v1.ConfigMap {
+	return &v1.ConfigMap{
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace: f.Namespace.Name,
+			Name:      name,
+		},
+		BinaryData: map[string][]byte{
+			"bin-data-1": binaryConfigMapPayload,
+		},
+	}
+}
+
+// projectedConfigMapSubpathPod builds the pod used by the projected
+// ConfigMap subPath/subPathExpr tests: a single container whose VolumeMounts
+// (and, when provided, Env) are supplied by the caller so SubPath and
+// SubPathExpr mounting can be exercised without duplicating pod boilerplate.
+func projectedConfigMapSubpathPod(configMapName string, volumeMounts []v1.VolumeMount, env []v1.EnvVar) *v1.Pod {
+	return &v1.Pod{
+		ObjectMeta: metav1.ObjectMeta{
+			Name: "pod-projected-configmaps-" + string(uuid.NewUUID()),
+		},
+		Spec: v1.PodSpec{
+			Volumes: []v1.Volume{
+				{
+					Name: "projected-configmap-volume",
+					VolumeSource: v1.VolumeSource{
+						Projected: &v1.ProjectedVolumeSource{
+							Sources: []v1.VolumeProjection{
+								{
+									ConfigMap: &v1.ConfigMapProjection{
+										LocalObjectReference: v1.LocalObjectReference{
+											Name: configMapName,
+										},
+									},
+								},
+							},
+						},
+					},
+				},
+			},
+			Containers: []v1.Container{
+				{
+					Name:         "projected-configmap-volume-test",
+					Image:        imageutils.GetE2EImage(imageutils.Mounttest),
+					Env:          env,
+					VolumeMounts: volumeMounts,
+				},
+			},
+			RestartPolicy: v1.RestartPolicyNever,
+		},
+	}
+}
+
+// projectedConfigMapUpdatePod builds the pod used by the projected ConfigMap
+// update and immutability tests: a single container that tails the content
+// of a projected ConfigMap's "data-1" key in a loop so callers can observe
+// how (or whether) changes to the ConfigMap propagate into the volume.
+func projectedConfigMapUpdatePod(volumeName, containerName, configMapName, containerTimeoutArg string) *v1.Pod {
+	volumeMountPath := "/etc/projected-configmap-volume"
+	return &v1.Pod{
+		ObjectMeta: metav1.ObjectMeta{
+			Name: "pod-projected-configmaps-" + string(uuid.NewUUID()),
+		},
+		Spec: v1.PodSpec{
+			Volumes: []v1.Volume{
+				{
+					Name: volumeName,
+					VolumeSource: v1.VolumeSource{
+						Projected: &v1.ProjectedVolumeSource{
+							Sources: []v1.VolumeProjection{
+								{
+									ConfigMap: &v1.ConfigMapProjection{
+										LocalObjectReference: v1.LocalObjectReference{
+											Name: configMapName,
+										},
+									},
+								},
+							},
+						},
+					},
+				},
+			},
+			Containers: []v1.Container{
+				{
+					Name:    containerName,
+					Image:   imageutils.GetE2EImage(imageutils.Mounttest),
+					Command: []string{"/mounttest", "--break_on_expected_content=false", containerTimeoutArg, "--file_content_in_loop=" + volumeMountPath + "/data-1"},
+					VolumeMounts: []v1.VolumeMount{
+						{
+							Name:      volumeName,
+							MountPath: volumeMountPath,
+							ReadOnly:  true,
+						},
+					},
+				},
+			},
+			RestartPolicy: v1.RestartPolicyNever,
+		},
+	}
+}
+
 func doProjectedConfigMapE2EWithoutMappings(f *framework.Framework, uid, fsGroup int64, defaultMode *int32) {
 	userID := int64(uid)
 	groupID := int64(fsGroup)